@@ -1,19 +1,23 @@
 // gen_test_vectors compiles a simple cubic circuit (x^3 + x + 5 == y),
-// runs Groth16 trusted setup, and exports .r1cs, .pk, .vk files to
+// runs trusted setup for the requested backend/curve combination, and
+// exports .r1cs (or .scs for PLONK), .pk, .vk files to
 // ../../tests/test-vectors/ for use in Rust integration tests.
 //
-// Usage: go run ./cmd/gen_test_vectors
+// Usage: go run ./cmd/gen_test_vectors [-backend groth16|plonk] [-curve bn254|bls12-381|bw6-761]
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
 )
 
 // CubicCircuit defines x^3 + x + 5 == y
@@ -29,50 +33,141 @@ func (circuit *CubicCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+func curveByName(name string) (ecc.ID, error) {
+	switch name {
+	case "bn254":
+		return ecc.BN254, nil
+	case "bls12-381":
+		return ecc.BLS12_381, nil
+	case "bw6-761":
+		return ecc.BW6_761, nil
+	default:
+		return 0, fmt.Errorf("unknown curve %q", name)
+	}
+}
+
 func main() {
+	backend := flag.String("backend", "groth16", "proving backend: groth16 or plonk")
+	curveName := flag.String("curve", "bn254", "curve: bn254, bls12-381, or bw6-761")
+	flag.Parse()
+
+	curveID, err := curveByName(*curveName)
+	if err != nil {
+		panic(err)
+	}
+
 	outDir := filepath.Join("..", "tests", "test-vectors")
 	if err := os.MkdirAll(outDir, 0o755); err != nil {
 		panic(fmt.Sprintf("failed to create output dir: %v", err))
 	}
 
 	var circuit CubicCircuit
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
-	if err != nil {
-		panic(fmt.Sprintf("failed to compile circuit: %v", err))
-	}
 
-	pk, vk, err := groth16.Setup(cs)
-	if err != nil {
-		panic(fmt.Sprintf("failed to run setup: %v", err))
+	if err := writeWitnessSchema(&circuit, filepath.Join(outDir, "cubic_circuit.witness_schema.json")); err != nil {
+		panic(fmt.Sprintf("failed to write witness schema: %v", err))
 	}
 
-	r1csFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.r1cs"))
-	if err != nil {
-		panic(fmt.Sprintf("failed to create r1cs file: %v", err))
-	}
-	defer r1csFile.Close()
-	if _, err := cs.WriteTo(r1csFile); err != nil {
-		panic(fmt.Sprintf("failed to write r1cs: %v", err))
-	}
+	switch *backend {
+	case "groth16":
+		cs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, &circuit)
+		if err != nil {
+			panic(fmt.Sprintf("failed to compile circuit: %v", err))
+		}
 
-	// WriteRawTo produces uncompressed binary, paired with UnsafeReadFrom on load
-	pkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.pk"))
-	if err != nil {
-		panic(fmt.Sprintf("failed to create pk file: %v", err))
-	}
-	defer pkFile.Close()
-	if _, err := pk.WriteRawTo(pkFile); err != nil {
-		panic(fmt.Sprintf("failed to write pk: %v", err))
-	}
+		pk, vk, err := groth16.Setup(cs)
+		if err != nil {
+			panic(fmt.Sprintf("failed to run setup: %v", err))
+		}
 
-	vkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.vk"))
-	if err != nil {
-		panic(fmt.Sprintf("failed to create vk file: %v", err))
-	}
-	defer vkFile.Close()
-	if _, err := vk.WriteTo(vkFile); err != nil {
-		panic(fmt.Sprintf("failed to write vk: %v", err))
+		r1csFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.r1cs"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create r1cs file: %v", err))
+		}
+		defer r1csFile.Close()
+		if _, err := cs.WriteTo(r1csFile); err != nil {
+			panic(fmt.Sprintf("failed to write r1cs: %v", err))
+		}
+
+		// WriteRawTo produces uncompressed binary, paired with UnsafeReadFrom on load
+		pkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.pk"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create pk file: %v", err))
+		}
+		defer pkFile.Close()
+		if _, err := pk.WriteRawTo(pkFile); err != nil {
+			panic(fmt.Sprintf("failed to write pk: %v", err))
+		}
+
+		vkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.vk"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create vk file: %v", err))
+		}
+		defer vkFile.Close()
+		if _, err := vk.WriteTo(vkFile); err != nil {
+			panic(fmt.Sprintf("failed to write vk: %v", err))
+		}
+
+		// Only BN254 Groth16 verifying keys have an on-chain Solidity verifier.
+		if curveID == ecc.BN254 {
+			solFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.sol"))
+			if err != nil {
+				panic(fmt.Sprintf("failed to create solidity file: %v", err))
+			}
+			defer solFile.Close()
+			if err := vk.ExportSolidity(solFile); err != nil {
+				panic(fmt.Sprintf("failed to export solidity verifier: %v", err))
+			}
+		}
+
+	case "plonk":
+		cs, err := frontend.Compile(curveID.ScalarField(), scs.NewBuilder, &circuit)
+		if err != nil {
+			panic(fmt.Sprintf("failed to compile circuit: %v", err))
+		}
+
+		// PLONK needs a universal SRS sized to the circuit; for test
+		// vectors we derive one in-process rather than ingesting a
+		// real Powers-of-Tau ceremony file.
+		srs, srsLagrange, err := unsafeTestSRS(cs)
+		if err != nil {
+			panic(fmt.Sprintf("failed to build test SRS: %v", err))
+		}
+
+		pk, vk, err := plonk.Setup(cs, srs, srsLagrange)
+		if err != nil {
+			panic(fmt.Sprintf("failed to run setup: %v", err))
+		}
+
+		scsFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.scs"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create scs file: %v", err))
+		}
+		defer scsFile.Close()
+		if _, err := cs.WriteTo(scsFile); err != nil {
+			panic(fmt.Sprintf("failed to write scs: %v", err))
+		}
+
+		pkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.pk"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create pk file: %v", err))
+		}
+		defer pkFile.Close()
+		if _, err := pk.WriteRawTo(pkFile); err != nil {
+			panic(fmt.Sprintf("failed to write pk: %v", err))
+		}
+
+		vkFile, err := os.Create(filepath.Join(outDir, "cubic_circuit.vk"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to create vk file: %v", err))
+		}
+		defer vkFile.Close()
+		if _, err := vk.WriteTo(vkFile); err != nil {
+			panic(fmt.Sprintf("failed to write vk: %v", err))
+		}
+
+	default:
+		panic(fmt.Sprintf("unknown backend %q", *backend))
 	}
 
-	fmt.Println("Test vectors generated successfully in", outDir)
+	fmt.Printf("Test vectors generated successfully in %s (backend=%s, curve=%s)\n", outDir, *backend, *curveName)
 }
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/consensys/gnark/frontend"
+
+	"github.com/vivianjeng/rust-gnark/go/internal/circuitschema"
+)
+
+// writeWitnessSchema emits the shape callers must use when building the
+// witness JSON accepted by gnark_groth16_prove/gnark_plonk_prove: every
+// leaf frontend.Variable path in the circuit, and whether it's public.
+func writeWitnessSchema(circuit frontend.Circuit, path string) error {
+	fields, err := circuitschema.Walk(circuit)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
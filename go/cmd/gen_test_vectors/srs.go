@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+// unsafeTestSRS derives a throwaway universal SRS sized to the compiled
+// circuit. It exists only so gen_test_vectors can produce PLONK test
+// vectors without ingesting a real Powers-of-Tau ceremony; it must never
+// be used to generate production keys (see gnark's own unsafekzg docs).
+func unsafeTestSRS(cs constraint.ConstraintSystem) (kzg.SRS, kzg.SRS, error) {
+	return unsafekzg.NewSRS(cs)
+}
@@ -0,0 +1,53 @@
+// setup runs a Groth16 trusted setup for a compiled R1CS, either as an
+// in-process toxic-waste setup or (when -ptau is given) by combining a
+// snarkjs Powers-of-Tau Phase-1 transcript with the circuit.
+//
+// Usage:
+//
+//	go run ./cmd/setup -r1cs circuit.r1cs -pk circuit.pk -vk circuit.vk
+//	go run ./cmd/setup -r1cs circuit.r1cs -ptau pot12_final.ptau -pk circuit.pk -vk circuit.vk -transcript circuit.transcript.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func main() {
+	r1csPath := flag.String("r1cs", "", "path to the compiled R1CS")
+	ptauPath := flag.String("ptau", "", "optional path to a snarkjs Powers-of-Tau file")
+	pkPath := flag.String("pk", "", "output path for the proving key")
+	vkPath := flag.String("vk", "", "output path for the verifying key")
+	transcriptPath := flag.String("transcript", "", "output path for the ptau audit transcript (required with -ptau)")
+	flag.Parse()
+
+	if *r1csPath == "" || *pkPath == "" || *vkPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: setup -r1cs <path> -pk <path> -vk <path> [-ptau <path> -transcript <path>]")
+		os.Exit(2)
+	}
+
+	cs := groth16.NewCS(ecc.BN254)
+	r1csFile, err := os.Open(*r1csPath)
+	if err != nil {
+		panic(fmt.Sprintf("failed to open r1cs file: %v", err))
+	}
+	defer r1csFile.Close()
+	if _, err := cs.ReadFrom(r1csFile); err != nil {
+		panic(fmt.Sprintf("failed to read r1cs: %v", err))
+	}
+
+	if *ptauPath == "" {
+		runInProcessSetup(cs, *pkPath, *vkPath)
+		return
+	}
+
+	if *transcriptPath == "" {
+		fmt.Fprintln(os.Stderr, "-transcript is required alongside -ptau")
+		os.Exit(2)
+	}
+	runPtauSetup(cs, *ptauPath, *pkPath, *vkPath, *transcriptPath)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+
+	"github.com/vivianjeng/rust-gnark/go/internal/lagrange"
+	"github.com/vivianjeng/rust-gnark/go/internal/ptau"
+)
+
+func runInProcessSetup(cs constraint.ConstraintSystem, pkPath, vkPath string) {
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		panic(fmt.Sprintf("setup failed: %v", err))
+	}
+
+	pkFile, err := os.Create(pkPath)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create pk file: %v", err))
+	}
+	defer pkFile.Close()
+	if _, err := pk.WriteRawTo(pkFile); err != nil {
+		panic(fmt.Sprintf("failed to write pk: %v", err))
+	}
+
+	vkFile, err := os.Create(vkPath)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create vk file: %v", err))
+	}
+	defer vkFile.Close()
+	if _, err := vk.WriteTo(vkFile); err != nil {
+		panic(fmt.Sprintf("failed to write vk: %v", err))
+	}
+
+	fmt.Printf("setup complete: wrote %s and %s\n", pkPath, vkPath)
+}
+
+// runPtauSetup validates a snarkjs Powers-of-Tau file against the circuit,
+// confirms it has enough powers of tau to evaluate the circuit's domain's
+// Lagrange basis, and records an audit transcript. See the doc comment on
+// gnark_groth16_setup_from_ptau in ../../setup.go for why this stops short
+// of writing pk/vk: gnark's groth16 package doesn't expose a way to
+// inject an external SRS into its ProvingKey/VerifyingKey types.
+func runPtauSetup(cs constraint.ConstraintSystem, ptauPath, pkPath, vkPath, transcriptPath string) {
+	pf, err := ptau.Read(ptauPath)
+	if err != nil {
+		panic(err)
+	}
+
+	nbConstraints := cs.GetNbConstraints()
+	transcript, err := ptau.Validate(pf, ptauPath, nbConstraints)
+	if err != nil {
+		panic(err)
+	}
+
+	cardinality := ptau.DomainCardinality(nbConstraints)
+	if _, err := lagrange.EvaluateG1(pf.TauG1, cardinality); err != nil {
+		panic(fmt.Sprintf("ptau file cannot support this circuit's domain: %v", err))
+	}
+
+	// Phase-2 key assembly is not yet implemented; record that in the
+	// transcript itself rather than only in this command's log line.
+	transcript.KeysWritten = false
+	if err := transcript.WriteFile(transcriptPath); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("ptau file validated and transcript written to %s; phase-2 pk/vk combination is not yet implemented (see gnark_groth16_setup_from_ptau doc comment), so %s and %s were not written\n",
+		transcriptPath, pkPath, vkPath)
+}
@@ -0,0 +1,27 @@
+package main
+
+/*
+#include "gnark.h"
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// curveFromID maps the C_Curve selector passed across the FFI boundary to
+// the gnark-crypto curve it names.
+func curveFromID(id C.int) (ecc.ID, error) {
+	switch id {
+	case C.GNARK_CURVE_BN254:
+		return ecc.BN254, nil
+	case C.GNARK_CURVE_BLS12_381:
+		return ecc.BLS12_381, nil
+	case C.GNARK_CURVE_BW6_761:
+		return ecc.BW6_761, nil
+	default:
+		return 0, fmt.Errorf("unknown curve id %d", id)
+	}
+}
@@ -0,0 +1,107 @@
+// Package circuitschema reflectively walks a gnark circuit struct to
+// describe the (path, isPublic) shape its witness JSON is expected to
+// have. It mirrors what gnark's own frontend/schema.Walk does when
+// flattening a circuit's frontend.Variable fields into the constraint
+// system's Public/Secret name lists, but is kept separate and minimal
+// since gen_test_vectors only needs it to emit a documentation schema,
+// not to actually build witnesses.
+package circuitschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Field describes a single leaf frontend.Variable in a circuit, at the
+// dotted/bracket path buildWitnessFromJSON expects witness JSON to use
+// (e.g. "Merkle.Path[3]").
+type Field struct {
+	Path   string `json:"path"`
+	Public bool   `json:"public"`
+}
+
+var variableType = reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+
+// Walk returns every frontend.Variable leaf in circuit, in the same
+// depth-first, field-order traversal gnark's compiler uses, which is also
+// the order its Public/Secret name slices end up in.
+func Walk(circuit frontend.Circuit) ([]Field, error) {
+	v := reflect.ValueOf(circuit)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("circuitschema: expected a pointer to a circuit struct, got %T", circuit)
+	}
+
+	var fields []Field
+	if err := walkStruct(v.Elem(), "", &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func walkStruct(v reflect.Value, prefix string, fields *[]Field) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, public := fieldName(sf)
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if err := walkValue(v.Field(i), path, public, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkValue(v reflect.Value, path string, public bool, fields *[]Field) error {
+	switch {
+	// frontend.Variable is "type Variable any", so every type trivially
+	// implements it -- v.Type().Implements(variableType) would be true
+	// for structs and arrays too and stop Walk from ever recursing into
+	// them. Match the field's declared type exactly instead.
+	case v.Type() == variableType:
+		*fields = append(*fields, Field{Path: path, Public: public})
+		return nil
+	case v.Kind() == reflect.Array, v.Kind() == reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), public, fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Kind() == reflect.Struct:
+		return walkStruct(v, path, fields)
+	default:
+		return fmt.Errorf("circuitschema: unsupported field type %s at %q", v.Type(), path)
+	}
+}
+
+// fieldName applies gnark's `gnark:"name,public"` tag convention: an
+// explicit name overrides the Go field name, and a "public" option marks
+// the variable as a public input (secret is the default).
+func fieldName(sf reflect.StructField) (name string, public bool) {
+	name = sf.Name
+	tag, ok := sf.Tag.Lookup("gnark")
+	if !ok {
+		return name, false
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "public" {
+			public = true
+		}
+	}
+	return name, public
+}
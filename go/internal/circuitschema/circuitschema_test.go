@@ -0,0 +1,70 @@
+package circuitschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+type merkleProof struct {
+	Path [3]frontend.Variable `gnark:"Path"`
+}
+
+type merkleCircuit struct {
+	X      frontend.Variable `gnark:"X"`
+	Y      frontend.Variable `gnark:"Y,public"`
+	Merkle merkleProof       `gnark:"Merkle"`
+}
+
+func (c *merkleCircuit) Define(api frontend.API) error { return nil }
+
+type untaggedCircuit struct {
+	A frontend.Variable
+}
+
+func (c *untaggedCircuit) Define(api frontend.API) error { return nil }
+
+// valueReceiverCircuit implements frontend.Circuit on the value type (not
+// just the pointer), so Walk can be called with a non-pointer argument to
+// exercise its "must be a pointer to a struct" guard.
+type valueReceiverCircuit struct {
+	A frontend.Variable
+}
+
+func (valueReceiverCircuit) Define(api frontend.API) error { return nil }
+
+func TestWalkArrayAndStructFields(t *testing.T) {
+	got, err := Walk(&merkleCircuit{})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+
+	want := []Field{
+		{Path: "X", Public: false},
+		{Path: "Y", Public: true},
+		{Path: "Merkle.Path[0]", Public: false},
+		{Path: "Merkle.Path[1]", Public: false},
+		{Path: "Merkle.Path[2]", Public: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk mismatch:\n got  %+v\n want %+v", got, want)
+	}
+}
+
+func TestWalkRejectsNonPointerCircuit(t *testing.T) {
+	if _, err := Walk(valueReceiverCircuit{}); err == nil {
+		t.Fatal("expected an error for a non-pointer circuit argument")
+	}
+}
+
+func TestFieldNameDefaultsToSecret(t *testing.T) {
+	got, err := Walk(&untaggedCircuit{})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	want := []Field{{Path: "A", Public: false}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk mismatch:\n got  %+v\n want %+v", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Package lagrange evaluates a domain's Lagrange basis polynomials at an
+// encrypted, unknown scalar tau, given only the powers of tau already
+// encrypted into curve points (as a Powers-of-Tau transcript provides).
+//
+// This is the piece of Groth16 Phase-2 key assembly that turns "powers of
+// tau in G1/G2" into "the values an R1CS's per-wire QAP polynomials need",
+// without ever learning tau itself: since the inverse-FFT that converts a
+// polynomial's monomial-basis coefficients into per-domain-point
+// evaluations (and vice versa) is a fixed linear transform over the
+// scalar field, the same transform can be applied to curve points instead
+// of field elements -- scalar multiplication by a fixed fr.Element
+// standing in for field multiplication, point addition for field
+// addition.
+package lagrange
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+)
+
+// EvaluateG1 returns, for a domain of the given cardinality, the vector
+// L_0(tau)*G1 .. L_{n-1}(tau)*G1 of Lagrange basis polynomials evaluated
+// at tau, given tauPowersG1[i] = tau^i * G1 for i in [0, cardinality).
+// cardinality must be a power of two.
+func EvaluateG1(tauPowersG1 []bn254.G1Affine, cardinality uint64) ([]bn254.G1Affine, error) {
+	domain, err := newDomain(cardinality, uint64(len(tauPowersG1)))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]bn254.G1Affine, cardinality)
+	for j := uint64(0); j < cardinality; j++ {
+		scalars := inverseFFTRow(domain, cardinality, j)
+		if _, err := out[j].MultiExp(tauPowersG1[:cardinality], scalars, ecc.MultiExpConfig{}); err != nil {
+			return nil, fmt.Errorf("lagrange: g1 multi-exp at index %d: %w", j, err)
+		}
+	}
+	return out, nil
+}
+
+// EvaluateG2 is EvaluateG1's G2 counterpart.
+func EvaluateG2(tauPowersG2 []bn254.G2Affine, cardinality uint64) ([]bn254.G2Affine, error) {
+	domain, err := newDomain(cardinality, uint64(len(tauPowersG2)))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]bn254.G2Affine, cardinality)
+	for j := uint64(0); j < cardinality; j++ {
+		scalars := inverseFFTRow(domain, cardinality, j)
+		if _, err := out[j].MultiExp(tauPowersG2[:cardinality], scalars, ecc.MultiExpConfig{}); err != nil {
+			return nil, fmt.Errorf("lagrange: g2 multi-exp at index %d: %w", j, err)
+		}
+	}
+	return out, nil
+}
+
+func newDomain(cardinality, nbAvailablePowers uint64) (*fft.Domain, error) {
+	if cardinality == 0 || cardinality&(cardinality-1) != 0 {
+		return nil, fmt.Errorf("lagrange: cardinality %d is not a power of two", cardinality)
+	}
+	if nbAvailablePowers < cardinality {
+		return nil, fmt.Errorf("lagrange: need %d powers of tau, only %d available", cardinality, nbAvailablePowers)
+	}
+	return fft.NewDomain(cardinality), nil
+}
+
+// inverseFFTRow returns, for output index j, the length-cardinality vector
+// of scalars (n^-1 * omega^-(i*j) for i in [0, cardinality)) that the
+// inverse DFT combines the i-th input point by to produce the j-th
+// Lagrange evaluation. This is the naive O(n) row of the O(n^2) transform
+// -- fine for the circuit sizes a single MSM-backed key assembly targets;
+// a production-scale version would want the actual (inverse) FFT instead
+// of materializing every row.
+func inverseFFTRow(domain *fft.Domain, cardinality, j uint64) []fr.Element {
+	var omegaInvJ fr.Element
+	omegaInvJ.Exp(domain.GeneratorInv, new(big.Int).SetUint64(j))
+
+	scalars := make([]fr.Element, cardinality)
+	acc := domain.CardinalityInv
+	for i := uint64(0); i < cardinality; i++ {
+		scalars[i].Set(&acc)
+		acc.Mul(&acc, &omegaInvJ)
+	}
+	return scalars
+}
@@ -0,0 +1,126 @@
+package lagrange
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+)
+
+// encryptedPowers returns tau^0*G1 .. tau^(n-1)*G1 for a chosen tau, the
+// shape a real Powers-of-Tau transcript's TauG1 section has.
+func encryptedPowers(t *testing.T, tau fr.Element, n uint64) []bn254.G1Affine {
+	t.Helper()
+	_, _, g1Gen, _ := bn254.Generators()
+
+	pts := make([]bn254.G1Affine, n)
+	power := fr.One()
+	for i := uint64(0); i < n; i++ {
+		var bi big.Int
+		power.BigInt(&bi)
+		pts[i].ScalarMultiplication(&g1Gen, &bi)
+		power.Mul(&power, &tau)
+	}
+	return pts
+}
+
+// directLagrangeAt evaluates the j-th Lagrange basis polynomial for a
+// size-n domain at x directly, via the textbook product formula, as a
+// cross-check independent of the IFFT-in-the-exponent machinery under
+// test.
+func directLagrangeAt(domain *fft.Domain, n uint64, j uint64, x fr.Element) fr.Element {
+	roots := make([]fr.Element, n)
+	roots[0] = fr.One()
+	for k := uint64(1); k < n; k++ {
+		roots[k].Mul(&roots[k-1], &domain.Generator)
+	}
+
+	num := fr.One()
+	den := fr.One()
+	for k := uint64(0); k < n; k++ {
+		if k == j {
+			continue
+		}
+		var diffX, diffRoots fr.Element
+		diffX.Sub(&x, &roots[k])
+		diffRoots.Sub(&roots[j], &roots[k])
+		num.Mul(&num, &diffX)
+		den.Mul(&den, &diffRoots)
+	}
+	den.Inverse(&den)
+	num.Mul(&num, &den)
+	return num
+}
+
+func TestEvaluateG1MatchesDirectLagrangeInterpolation(t *testing.T) {
+	const n = uint64(4)
+	domain := fft.NewDomain(n)
+
+	var tau fr.Element
+	tau.SetUint64(7) // any non-root scalar works; fixed for a reproducible test
+
+	tauPowersG1 := encryptedPowers(t, tau, n)
+
+	got, err := EvaluateG1(tauPowersG1, n)
+	if err != nil {
+		t.Fatalf("EvaluateG1 failed: %v", err)
+	}
+
+	_, _, g1Gen, _ := bn254.Generators()
+	for j := uint64(0); j < n; j++ {
+		lj := directLagrangeAt(domain, n, j, tau)
+		var ljBig big.Int
+		lj.BigInt(&ljBig)
+		var want bn254.G1Affine
+		want.ScalarMultiplication(&g1Gen, &ljBig)
+
+		if !got[j].Equal(&want) {
+			t.Fatalf("L_%d(tau)*G1 = %v, want %v", j, got[j], want)
+		}
+	}
+}
+
+func TestEvaluateG1SumIsPartitionOfUnity(t *testing.T) {
+	// sum_j L_j(x) == 1 for any x, since the L_j form a partition of
+	// unity -- a property-based check independent of which tau was used.
+	const n = uint64(8)
+
+	var tau fr.Element
+	tau.SetUint64(123)
+
+	tauPowersG1 := encryptedPowers(t, tau, n)
+	got, err := EvaluateG1(tauPowersG1, n)
+	if err != nil {
+		t.Fatalf("EvaluateG1 failed: %v", err)
+	}
+
+	sum := new(bn254.G1Jac)
+	for j := range got {
+		var jac bn254.G1Jac
+		jac.FromAffine(&got[j])
+		sum.AddAssign(&jac)
+	}
+	var sumAffine bn254.G1Affine
+	sumAffine.FromJacobian(sum)
+
+	_, _, g1Gen, _ := bn254.Generators()
+	if !sumAffine.Equal(&g1Gen) {
+		t.Fatalf("sum of Lagrange basis evaluations = %v, want the G1 generator %v", sumAffine, g1Gen)
+	}
+}
+
+func TestEvaluateG1RejectsNonPowerOfTwoCardinality(t *testing.T) {
+	_, _, g1Gen, _ := bn254.Generators()
+	if _, err := EvaluateG1([]bn254.G1Affine{g1Gen, g1Gen, g1Gen}, 3); err == nil {
+		t.Fatal("expected an error for a non-power-of-two cardinality")
+	}
+}
+
+func TestEvaluateG1RejectsTooFewPowers(t *testing.T) {
+	_, _, g1Gen, _ := bn254.Generators()
+	if _, err := EvaluateG1([]bn254.G1Affine{g1Gen}, 4); err == nil {
+		t.Fatal("expected an error when fewer powers of tau are supplied than the domain needs")
+	}
+}
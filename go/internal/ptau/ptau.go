@@ -0,0 +1,215 @@
+// Package ptau parses snarkjs Phase-1 Powers-of-Tau transcripts (.ptau
+// files, BN254 only) so they can be combined with a compiled circuit to
+// derive Groth16 Phase-2 keys without an in-process toxic-waste setup.
+package ptau
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// section identifies one of the section types defined by the snarkjs
+// Powers-of-Tau file format.
+const (
+	sectionHeader     = 1
+	sectionTauG1      = 2
+	sectionTauG2      = 3
+	sectionAlphaTauG1 = 4
+	sectionBetaTauG1  = 5
+	sectionBetaG2     = 6
+	sectionContribs   = 7
+)
+
+// File is the subset of a parsed Phase-1 Powers-of-Tau transcript needed
+// to derive Groth16 Phase-2 proving/verifying keys: the encrypted powers
+// of tau in G1 and G2, and the alpha/beta-shifted powers used to blind
+// the A/B/C polynomials.
+//
+// File layout: 4-byte magic "ptau", a uint32 version, a uint32 section
+// count, then that many (sectionID uint32, sectionSize uint64) headers
+// each immediately followed by sectionSize bytes of section payload.
+type File struct {
+	Power         uint32 // 2^Power is the max number of constraints supported
+	TauG1         []bn254.G1Affine
+	TauG2         []bn254.G2Affine
+	AlphaTauG1    []bn254.G1Affine
+	BetaTauG1     []bn254.G1Affine
+	BetaG2        bn254.G2Affine
+	Contributions []byte // raw, opaque contribution transcript, kept for the audit trail
+}
+
+// Read parses a snarkjs Phase-1 Powers-of-Tau file.
+func Read(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ptau file: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("failed to read ptau magic: %w", err)
+	}
+	if string(magic[:]) != "ptau" {
+		return nil, fmt.Errorf("not a ptau file: bad magic %q", magic)
+	}
+
+	var version uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("failed to read ptau version: %w", err)
+	}
+
+	var nbSections uint32
+	if err := binary.Read(r, binary.LittleEndian, &nbSections); err != nil {
+		return nil, fmt.Errorf("failed to read ptau section count: %w", err)
+	}
+
+	pf := &File{}
+
+	for i := uint32(0); i < nbSections; i++ {
+		var sectionID uint32
+		var sectionSize uint64
+		if err := binary.Read(r, binary.LittleEndian, &sectionID); err != nil {
+			return nil, fmt.Errorf("failed to read section id: %w", err)
+		}
+		if err := binary.Read(r, binary.LittleEndian, &sectionSize); err != nil {
+			return nil, fmt.Errorf("failed to read section size: %w", err)
+		}
+
+		payload := make([]byte, sectionSize)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("failed to read section %d payload: %w", sectionID, err)
+		}
+
+		if err := pf.parseSection(sectionID, payload); err != nil {
+			return nil, fmt.Errorf("failed to parse section %d: %w", sectionID, err)
+		}
+	}
+
+	if pf.Power == 0 {
+		return nil, fmt.Errorf("ptau file missing header section")
+	}
+
+	return pf, nil
+}
+
+func (pf *File) parseSection(id uint32, payload []byte) error {
+	switch id {
+	case sectionHeader:
+		return pf.parseHeader(payload)
+	case sectionTauG1:
+		pts, err := readG1Affines(payload)
+		if err != nil {
+			return err
+		}
+		pf.TauG1 = pts
+	case sectionTauG2:
+		pts, err := readG2Affines(payload)
+		if err != nil {
+			return err
+		}
+		pf.TauG2 = pts
+	case sectionAlphaTauG1:
+		pts, err := readG1Affines(payload)
+		if err != nil {
+			return err
+		}
+		pf.AlphaTauG1 = pts
+	case sectionBetaTauG1:
+		pts, err := readG1Affines(payload)
+		if err != nil {
+			return err
+		}
+		pf.BetaTauG1 = pts
+	case sectionBetaG2:
+		pts, err := readG2Affines(payload)
+		if err != nil {
+			return err
+		}
+		if len(pts) != 1 {
+			return fmt.Errorf("expected exactly one betaG2 point, got %d", len(pts))
+		}
+		pf.BetaG2 = pts[0]
+	case sectionContribs:
+		pf.Contributions = payload
+	default:
+		// Unknown/forward-compatible section: ignore.
+	}
+	return nil
+}
+
+// ptau header section: uint32 field size in bytes, field prime (field size
+// bytes), uint32 power.
+func (pf *File) parseHeader(payload []byte) error {
+	if len(payload) < 4 {
+		return fmt.Errorf("header section too short")
+	}
+	fieldSize := binary.LittleEndian.Uint32(payload[0:4])
+	offset := 4 + int(fieldSize)
+	if len(payload) < offset+4 {
+		return fmt.Errorf("header section missing power field")
+	}
+	pf.Power = binary.LittleEndian.Uint32(payload[offset : offset+4])
+	return nil
+}
+
+// readG1Affines decodes a tightly packed array of uncompressed BN254 G1
+// points. snarkjs stores each coordinate as a raw (non-Montgomery),
+// little-endian 32-byte field element, x then y -- NOT gnark-crypto's own
+// Unmarshal format, which expects big-endian bytes with compression/
+// infinity flag bits packed into the top two bits. The zero point (x=y=0,
+// snarkjs's encoding of the point at infinity) decodes to bn254's affine
+// identity, same as gnark-crypto's own convention.
+func readG1Affines(payload []byte) ([]bn254.G1Affine, error) {
+	const coordSize = 32
+	const pointSize = 2 * coordSize
+	if len(payload)%pointSize != 0 {
+		return nil, fmt.Errorf("g1 section size %d is not a multiple of %d", len(payload), pointSize)
+	}
+	pts := make([]bn254.G1Affine, len(payload)/pointSize)
+	for i := range pts {
+		buf := payload[i*pointSize : (i+1)*pointSize]
+		pts[i].X.SetBytes(reversed(buf[0:coordSize]))
+		pts[i].Y.SetBytes(reversed(buf[coordSize:pointSize]))
+	}
+	return pts, nil
+}
+
+// readG2Affines decodes a tightly packed array of uncompressed BN254 G2
+// points, using the same raw little-endian coordinate encoding as
+// readG1Affines. Each G2 point is four field elements -- X.A0, X.A1, Y.A0,
+// Y.A1, in snarkjs's fp2 ordering -- rather than two.
+func readG2Affines(payload []byte) ([]bn254.G2Affine, error) {
+	const coordSize = 32
+	const pointSize = 4 * coordSize
+	if len(payload)%pointSize != 0 {
+		return nil, fmt.Errorf("g2 section size %d is not a multiple of %d", len(payload), pointSize)
+	}
+	pts := make([]bn254.G2Affine, len(payload)/pointSize)
+	for i := range pts {
+		buf := payload[i*pointSize : (i+1)*pointSize]
+		pts[i].X.A0.SetBytes(reversed(buf[0*coordSize : 1*coordSize]))
+		pts[i].X.A1.SetBytes(reversed(buf[1*coordSize : 2*coordSize]))
+		pts[i].Y.A0.SetBytes(reversed(buf[2*coordSize : 3*coordSize]))
+		pts[i].Y.A1.SetBytes(reversed(buf[3*coordSize : 4*coordSize]))
+	}
+	return pts, nil
+}
+
+// reversed returns a copy of b with byte order reversed, converting
+// snarkjs's little-endian coordinate encoding into the big-endian order
+// fp.Element.SetBytes expects.
+func reversed(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[len(b)-1-i] = c
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+package ptau
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// leCoord returns the little-endian, raw (non-Montgomery) 32-byte encoding
+// snarkjs uses for a single field coordinate -- the byte-reversal of
+// gnark-crypto's own big-endian fp.Element.Bytes().
+func leCoord(be [32]byte) []byte {
+	out := make([]byte, 32)
+	for i, b := range be {
+		out[31-i] = b
+	}
+	return out
+}
+
+func encodeG1(p bn254.G1Affine) []byte {
+	var buf bytes.Buffer
+	xb := p.X.Bytes()
+	yb := p.Y.Bytes()
+	buf.Write(leCoord(xb))
+	buf.Write(leCoord(yb))
+	return buf.Bytes()
+}
+
+func encodeG2(p bn254.G2Affine) []byte {
+	var buf bytes.Buffer
+	buf.Write(leCoord(p.X.A0.Bytes()))
+	buf.Write(leCoord(p.X.A1.Bytes()))
+	buf.Write(leCoord(p.Y.A0.Bytes()))
+	buf.Write(leCoord(p.Y.A1.Bytes()))
+	return buf.Bytes()
+}
+
+// buildFixture assembles a minimal, well-formed ptau file (a header
+// section and a single-point tauG1 section; g2 is not written) in memory,
+// the way a tiny snarkjs ceremony output would be laid out.
+func buildFixture(t *testing.T, power uint32, g1 bn254.G1Affine, g2 bn254.G2Affine) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	out.WriteString("ptau")
+	binary.Write(&out, binary.LittleEndian, uint32(1)) // version
+	binary.Write(&out, binary.LittleEndian, uint32(2)) // nbSections: header + tauG1
+
+	// Section 1: header (fieldSize uint32, prime bytes, power uint32).
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, uint32(32))
+	header.Write(make([]byte, 32)) // prime bytes, unused by our parser
+	binary.Write(&header, binary.LittleEndian, power)
+	binary.Write(&out, binary.LittleEndian, uint32(sectionHeader))
+	binary.Write(&out, binary.LittleEndian, uint64(header.Len()))
+	out.Write(header.Bytes())
+
+	// Section 2: tauG1, a single point.
+	g1Bytes := encodeG1(g1)
+	binary.Write(&out, binary.LittleEndian, uint32(sectionTauG1))
+	binary.Write(&out, binary.LittleEndian, uint64(len(g1Bytes)))
+	out.Write(g1Bytes)
+
+	_ = g2 // reserved for callers that also want a tauG2 section
+
+	path := filepath.Join(t.TempDir(), "fixture.ptau")
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestReadDecodesGeneratorPoint(t *testing.T) {
+	_, _, g1Gen, _ := bn254.Generators()
+
+	path := buildFixture(t, 3, g1Gen, bn254.G2Affine{})
+
+	pf, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if pf.Power != 3 {
+		t.Fatalf("expected power 3, got %d", pf.Power)
+	}
+	if len(pf.TauG1) != 1 {
+		t.Fatalf("expected 1 tauG1 point, got %d", len(pf.TauG1))
+	}
+	if !pf.TauG1[0].Equal(&g1Gen) {
+		t.Fatalf("decoded g1 point %v does not match generator %v", pf.TauG1[0], g1Gen)
+	}
+}
+
+func TestReadG1AffinesRejectsTruncatedPayload(t *testing.T) {
+	if _, err := readG1Affines(make([]byte, 63)); err == nil {
+		t.Fatal("expected an error for a payload that isn't a multiple of the point size")
+	}
+}
+
+func TestReadG1AffinesDecodesInfinity(t *testing.T) {
+	pts, err := readG1Affines(make([]byte, 64)) // all-zero x, y
+	if err != nil {
+		t.Fatalf("readG1Affines failed: %v", err)
+	}
+	if !pts[0].IsInfinity() {
+		t.Fatalf("expected the all-zero point to decode as infinity, got %v", pts[0])
+	}
+}
+
+func TestReadG2AffinesRoundTrip(t *testing.T) {
+	_, _, _, g2Gen := bn254.Generators()
+
+	pts, err := readG2Affines(encodeG2(g2Gen))
+	if err != nil {
+		t.Fatalf("readG2Affines failed: %v", err)
+	}
+	if !pts[0].Equal(&g2Gen) {
+		t.Fatalf("decoded g2 point %v does not match generator %v", pts[0], g2Gen)
+	}
+}
+
+func TestReadRejectsBadMagic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.ptau")
+	if err := os.WriteFile(path, []byte("notptau"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if _, err := Read(path); err == nil {
+		t.Fatal("expected an error for a file with a bad magic header")
+	}
+}
@@ -0,0 +1,67 @@
+package ptau
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Transcript is the JSON sidecar emitted next to a ptau-derived key pair,
+// recording enough about the ceremony file to audit where the setup's
+// randomness came from. It's shared by every entry point that combines a
+// ptau file with a circuit (the FFI export and the cmd/setup CLI) so they
+// can't drift into emitting different shapes.
+type Transcript struct {
+	PtauPath            string `json:"ptau_path"`
+	PtauPower           uint32 `json:"ptau_power"`
+	NbConstraints       int    `json:"nb_constraints"`
+	NbContributionBytes int    `json:"nb_contribution_bytes"`
+
+	// KeysWritten is false when the caller stopped short of the Phase-2
+	// pk/vk assembly (the ptau file validated and the Lagrange-at-tau
+	// basis evaluated, but no ProvingKey/VerifyingKey was produced), so
+	// callers reading the transcript back can tell "validated only" apart
+	// from "validated and keys written" without that distinction being
+	// folded into the FFI call's own success/failure signal.
+	KeysWritten bool `json:"keys_written"`
+}
+
+// Validate checks that a parsed ptau file can support a circuit with the
+// given number of constraints and builds the Transcript describing the
+// pairing.
+func Validate(pf *File, ptauPath string, nbConstraints int) (Transcript, error) {
+	if maxConstraints := uint64(1) << pf.Power; uint64(nbConstraints) > maxConstraints {
+		return Transcript{}, fmt.Errorf("circuit has %d constraints, ptau only supports up to 2^%d=%d",
+			nbConstraints, pf.Power, maxConstraints)
+	}
+	return Transcript{
+		PtauPath:            ptauPath,
+		PtauPower:           pf.Power,
+		NbConstraints:       nbConstraints,
+		NbContributionBytes: len(pf.Contributions),
+	}, nil
+}
+
+// WriteFile marshals the transcript as indented JSON to path.
+func (tr Transcript) WriteFile(path string) error {
+	b, err := json.MarshalIndent(tr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ptau transcript: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write ptau transcript: %w", err)
+	}
+	return nil
+}
+
+// DomainCardinality returns the smallest power of two >= nbConstraints --
+// the FFT domain size the circuit's QAP polynomials are interpolated
+// over, and so the number of Lagrange basis points a Phase-2 combination
+// needs from the ptau file's encrypted powers of tau.
+func DomainCardinality(nbConstraints int) uint64 {
+	n := uint64(1)
+	for n < uint64(nbConstraints) {
+		n <<= 1
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+package ptau
+
+import "testing"
+
+func TestValidateRejectsTooManyConstraints(t *testing.T) {
+	pf := &File{Power: 2} // supports up to 4 constraints
+	if _, err := Validate(pf, "x.ptau", 5); err == nil {
+		t.Fatal("expected an error when nbConstraints exceeds 2^power")
+	}
+}
+
+func TestValidateOK(t *testing.T) {
+	pf := &File{Power: 3, Contributions: []byte{1, 2, 3}}
+	tr, err := Validate(pf, "x.ptau", 5)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if tr.PtauPower != 3 || tr.NbConstraints != 5 || tr.NbContributionBytes != 3 || tr.PtauPath != "x.ptau" {
+		t.Fatalf("unexpected transcript: %+v", tr)
+	}
+}
+
+func TestDomainCardinality(t *testing.T) {
+	cases := map[int]uint64{0: 1, 1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 8: 8, 9: 16}
+	for nbConstraints, want := range cases {
+		if got := DomainCardinality(nbConstraints); got != want {
+			t.Errorf("DomainCardinality(%d) = %d, want %d", nbConstraints, got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+/*
+#include "gnark.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// PLONK proving/verifying keys are universal-SRS derived and are expected
+// to already exist on disk (e.g. produced by gnark's own setup tooling);
+// this package only drives prove/verify, mirroring the Groth16 exports in
+// wrapper.go.
+
+//export gnark_plonk_prove
+func gnark_plonk_prove(
+	curve C.int,
+	scs_path *C.char,
+	pk_path *C.char,
+	witness_json *C.char,
+) *C.C_ProofResult {
+	result := (*C.C_ProofResult)(C.malloc(C.size_t(unsafe.Sizeof(C.C_ProofResult{}))))
+	result.proof = nil
+	result.public_inputs = nil
+	result.error = nil
+
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		result.error = C.CString(err.Error())
+		return result
+	}
+
+	cs := plonk.NewCS(curveID)
+	scsFile, err := os.Open(C.GoString(scs_path))
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to open sparse r1cs file: %v", err))
+		return result
+	}
+	defer scsFile.Close()
+
+	if _, err := cs.ReadFrom(scsFile); err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to read sparse r1cs: %v", err))
+		return result
+	}
+
+	pk := plonk.NewProvingKey(curveID)
+	pkFile, err := os.Open(C.GoString(pk_path))
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to open pk file: %v", err))
+		return result
+	}
+	defer pkFile.Close()
+
+	if _, err := pk.UnsafeReadFrom(pkFile); err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to read proving key: %v", err))
+		return result
+	}
+
+	witnessJSON := C.GoString(witness_json)
+	fullWitness, err := buildWitnessFromJSON(witnessJSON, cs)
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to build witness: %v", err))
+		return result
+	}
+
+	proof, err := plonk.Prove(cs, pk, fullWitness)
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("proof generation failed: %v", err))
+		return result
+	}
+
+	var proofBuf bytes.Buffer
+	if _, err := proof.WriteTo(&proofBuf); err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to serialize proof: %v", err))
+		return result
+	}
+	result.proof = C.CString(hex.EncodeToString(proofBuf.Bytes()))
+
+	pubWitness, err := fullWitness.Public()
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to extract public witness: %v", err))
+		return result
+	}
+	pubBin, err := pubWitness.MarshalBinary()
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to marshal public witness: %v", err))
+		return result
+	}
+	result.public_inputs = C.CString(hex.EncodeToString(pubBin))
+
+	return result
+}
+
+//export gnark_plonk_verify
+func gnark_plonk_verify(
+	curve C.int,
+	scs_path *C.char,
+	vk_path *C.char,
+	proof_hex *C.char,
+	public_inputs_hex *C.char,
+) *C.char {
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	cs := plonk.NewCS(curveID)
+	scsFile, err := os.Open(C.GoString(scs_path))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to open sparse r1cs file: %v", err))
+	}
+	defer scsFile.Close()
+
+	if _, err := cs.ReadFrom(scsFile); err != nil {
+		return C.CString(fmt.Sprintf("failed to read sparse r1cs: %v", err))
+	}
+
+	vk := plonk.NewVerifyingKey(curveID)
+	vkFile, err := os.Open(C.GoString(vk_path))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to open vk file: %v", err))
+	}
+	defer vkFile.Close()
+
+	if _, err := vk.ReadFrom(vkFile); err != nil {
+		return C.CString(fmt.Sprintf("failed to read verifying key: %v", err))
+	}
+
+	proofBytes, err := hex.DecodeString(C.GoString(proof_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode proof hex: %v", err))
+	}
+	proof := plonk.NewProof(curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return C.CString(fmt.Sprintf("failed to deserialize proof: %v", err))
+	}
+
+	pubBytes, err := hex.DecodeString(C.GoString(public_inputs_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode public inputs hex: %v", err))
+	}
+	pubWitness, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to create witness: %v", err))
+	}
+	if err := pubWitness.UnmarshalBinary(pubBytes); err != nil {
+		return C.CString(fmt.Sprintf("failed to unmarshal public witness: %v", err))
+	}
+
+	if err := plonk.Verify(proof, vk, pubWitness); err != nil {
+		return C.CString(fmt.Sprintf("invalid proof: %v", err))
+	}
+
+	// NULL = valid proof
+	return nil
+}
@@ -0,0 +1,327 @@
+package main
+
+/*
+#include "gnark.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// Sessions amortize the cost of re-reading and re-parsing an R1CS and
+// proving/verifying key on every call, which otherwise dominates latency
+// for short-lived CLI callers. A session opens its artifacts once, caches
+// the variable-name slices buildWitnessFromJSON needs, and is addressed
+// by an opaque handle so it can be driven from Rust across FFI calls.
+//
+// Handles are allocated from one shared counter and stored in one shared
+// map so gnark_session_stats doesn't need to know which kind of session
+// it's looking at.
+
+type sessionKind int
+
+const (
+	sessionKindProver sessionKind = iota
+	sessionKindVerifier
+)
+
+type session struct {
+	kind sessionKind
+
+	mu      sync.Mutex
+	curveID ecc.ID
+	cs      constraint.ConstraintSystem
+
+	// prover-only
+	pk                groth16.ProvingKey
+	lastProveDuration time.Duration
+
+	// verifier-only
+	vk groth16.VerifyingKey
+
+	publicNames []string
+	secretNames []string
+}
+
+var (
+	sessions      sync.Map // uint64 -> *session
+	nextSessionID uint64   // atomic, first handle issued is 1; 0 means "invalid"
+)
+
+func registerSession(s *session) uint64 {
+	handle := atomic.AddUint64(&nextSessionID, 1)
+	sessions.Store(handle, s)
+	return handle
+}
+
+func lookupSession(handle uint64) (*session, bool) {
+	v, ok := sessions.Load(handle)
+	if !ok {
+		return nil, false
+	}
+	return v.(*session), true
+}
+
+// gnark_groth16_session_open reads an R1CS and proving key once and
+// returns a handle callers reuse across many gnark_groth16_session_prove
+// calls instead of re-reading them from disk each time.
+//
+//export gnark_groth16_session_open
+func gnark_groth16_session_open(
+	curve C.int,
+	r1cs_path *C.char,
+	pk_path *C.char,
+	error_out **C.char,
+) C.uint64_t {
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return failSession(error_out, err)
+	}
+
+	cs := groth16.NewCS(curveID)
+	r1csFile, err := os.Open(C.GoString(r1cs_path))
+	if err != nil {
+		return failSession(error_out, fmt.Errorf("failed to open r1cs file: %w", err))
+	}
+	defer r1csFile.Close()
+	if _, err := cs.ReadFrom(r1csFile); err != nil {
+		return failSession(error_out, fmt.Errorf("failed to read r1cs: %w", err))
+	}
+
+	pk := groth16.NewProvingKey(curveID)
+	pkFile, err := os.Open(C.GoString(pk_path))
+	if err != nil {
+		return failSession(error_out, fmt.Errorf("failed to open pk file: %w", err))
+	}
+	defer pkFile.Close()
+	if _, err := pk.UnsafeReadFrom(pkFile); err != nil {
+		return failSession(error_out, fmt.Errorf("failed to read proving key: %w", err))
+	}
+
+	publicNames, secretNames, err := variableNames(cs)
+	if err != nil {
+		return failSession(error_out, err)
+	}
+	publicNames = publicNames[1:] // skip the "1" constant wire
+
+	handle := registerSession(&session{
+		kind:        sessionKindProver,
+		curveID:     curveID,
+		cs:          cs,
+		pk:          pk,
+		publicNames: publicNames,
+		secretNames: secretNames,
+	})
+	return C.uint64_t(handle)
+}
+
+// gnark_groth16_session_prove builds a witness and proves against a
+// session opened with gnark_groth16_session_open, without re-reading or
+// re-parsing the r1cs/pk. Concurrent calls against the same handle are
+// serialized; concurrent calls against different handles run in parallel.
+//
+//export gnark_groth16_session_prove
+func gnark_groth16_session_prove(handle C.uint64_t, witness_json *C.char) *C.C_ProofResult {
+	result := (*C.C_ProofResult)(C.malloc(C.size_t(unsafe.Sizeof(C.C_ProofResult{}))))
+	result.proof = nil
+	result.public_inputs = nil
+	result.error = nil
+
+	s, ok := lookupSession(uint64(handle))
+	if !ok || s.kind != sessionKindProver {
+		result.error = C.CString(fmt.Sprintf("unknown prover session handle %d", uint64(handle)))
+		return result
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := time.Now()
+
+	fullWitness, err := buildWitnessFromNames(C.GoString(witness_json), s.cs.Field(), s.publicNames, s.secretNames)
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to build witness: %v", err))
+		return result
+	}
+
+	proof, err := groth16.Prove(s.cs, s.pk, fullWitness)
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("proof generation failed: %v", err))
+		return result
+	}
+	s.lastProveDuration = time.Since(start)
+
+	var proofBuf bytes.Buffer
+	if _, err := proof.WriteTo(&proofBuf); err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to serialize proof: %v", err))
+		return result
+	}
+	result.proof = C.CString(hex.EncodeToString(proofBuf.Bytes()))
+
+	pubWitness, err := fullWitness.Public()
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to extract public witness: %v", err))
+		return result
+	}
+	pubBin, err := pubWitness.MarshalBinary()
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("failed to marshal public witness: %v", err))
+		return result
+	}
+	result.public_inputs = C.CString(hex.EncodeToString(pubBin))
+
+	return result
+}
+
+//export gnark_groth16_session_close
+func gnark_groth16_session_close(handle C.uint64_t) {
+	sessions.Delete(uint64(handle))
+}
+
+// gnark_verifier_session_open is the verify-side counterpart of
+// gnark_groth16_session_open: it caches a parsed r1cs and verifying key
+// under a handle drawn from the same counter and map as prover sessions.
+//
+//export gnark_verifier_session_open
+func gnark_verifier_session_open(
+	curve C.int,
+	r1cs_path *C.char,
+	vk_path *C.char,
+	error_out **C.char,
+) C.uint64_t {
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return failSession(error_out, err)
+	}
+
+	cs := groth16.NewCS(curveID)
+	r1csFile, err := os.Open(C.GoString(r1cs_path))
+	if err != nil {
+		return failSession(error_out, fmt.Errorf("failed to open r1cs file: %w", err))
+	}
+	defer r1csFile.Close()
+	if _, err := cs.ReadFrom(r1csFile); err != nil {
+		return failSession(error_out, fmt.Errorf("failed to read r1cs: %w", err))
+	}
+
+	vk := groth16.NewVerifyingKey(curveID)
+	vkFile, err := os.Open(C.GoString(vk_path))
+	if err != nil {
+		return failSession(error_out, fmt.Errorf("failed to open vk file: %w", err))
+	}
+	defer vkFile.Close()
+	if _, err := vk.ReadFrom(vkFile); err != nil {
+		return failSession(error_out, fmt.Errorf("failed to read verifying key: %w", err))
+	}
+
+	publicNames, secretNames, err := variableNames(cs)
+	if err != nil {
+		return failSession(error_out, err)
+	}
+	publicNames = publicNames[1:] // skip the "1" constant wire
+
+	handle := registerSession(&session{
+		kind:        sessionKindVerifier,
+		curveID:     curveID,
+		cs:          cs,
+		vk:          vk,
+		publicNames: publicNames,
+		secretNames: secretNames,
+	})
+	return C.uint64_t(handle)
+}
+
+//export gnark_verifier_session_verify
+func gnark_verifier_session_verify(handle C.uint64_t, proof_hex *C.char, public_inputs_hex *C.char) *C.char {
+	s, ok := lookupSession(uint64(handle))
+	if !ok || s.kind != sessionKindVerifier {
+		return C.CString(fmt.Sprintf("unknown verifier session handle %d", uint64(handle)))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proofBytes, err := hex.DecodeString(C.GoString(proof_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode proof hex: %v", err))
+	}
+	proof := groth16.NewProof(s.curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return C.CString(fmt.Sprintf("failed to deserialize proof: %v", err))
+	}
+
+	pubBytes, err := hex.DecodeString(C.GoString(public_inputs_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode public inputs hex: %v", err))
+	}
+	pubWitness, err := witness.New(s.cs.Field())
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to create witness: %v", err))
+	}
+	if err := pubWitness.UnmarshalBinary(pubBytes); err != nil {
+		return C.CString(fmt.Sprintf("failed to unmarshal public witness: %v", err))
+	}
+
+	if err := groth16.Verify(proof, s.vk, pubWitness); err != nil {
+		return C.CString(fmt.Sprintf("invalid proof: %v", err))
+	}
+
+	return nil
+}
+
+//export gnark_verifier_session_close
+func gnark_verifier_session_close(handle C.uint64_t) {
+	sessions.Delete(uint64(handle))
+}
+
+// sessionStats is the JSON shape gnark_session_stats returns.
+type sessionStats struct {
+	NbConstraints       int   `json:"nb_constraints"`
+	NbPublicVariables   int   `json:"nb_public_variables"`
+	NbSecretVariables   int   `json:"nb_secret_variables"`
+	LastProveDurationMs int64 `json:"last_prove_duration_ms"`
+}
+
+//export gnark_session_stats
+func gnark_session_stats(handle C.uint64_t) *C.char {
+	s, ok := lookupSession(uint64(handle))
+	if !ok {
+		return C.CString(fmt.Sprintf(`{"error":"unknown session handle %d"}`, uint64(handle)))
+	}
+
+	s.mu.Lock()
+	stats := sessionStats{
+		NbConstraints:       s.cs.GetNbConstraints(),
+		NbPublicVariables:   len(s.publicNames),
+		NbSecretVariables:   len(s.secretNames),
+		LastProveDurationMs: s.lastProveDuration.Milliseconds(),
+	}
+	s.mu.Unlock()
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return C.CString(fmt.Sprintf(`{"error":%q}`, err.Error()))
+	}
+	return C.CString(string(b))
+}
+
+func failSession(error_out **C.char, err error) C.uint64_t {
+	if error_out != nil {
+		*error_out = C.CString(err.Error())
+	}
+	return 0
+}
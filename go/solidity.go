@@ -0,0 +1,135 @@
+package main
+
+/*
+#include "gnark.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// gnark_groth16_export_solidity writes an on-chain Groth16 verifier
+// contract (Verifier.sol) for a BN254 verifying key, using gnark's own
+// VerifyingKey.ExportSolidity. Solidity verifiers are only meaningful for
+// the curve the EVM's precompiles support, so this does not take a curve
+// selector the way the prove/verify exports do.
+//
+//export gnark_groth16_export_solidity
+func gnark_groth16_export_solidity(vk_path *C.char, out_path *C.char) *C.char {
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	vkFile, err := os.Open(C.GoString(vk_path))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to open vk file: %v", err))
+	}
+	defer vkFile.Close()
+
+	if _, err := vk.ReadFrom(vkFile); err != nil {
+		return C.CString(fmt.Sprintf("failed to read verifying key: %v", err))
+	}
+
+	outFile, err := os.Create(C.GoString(out_path))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to create solidity output file: %v", err))
+	}
+	defer outFile.Close()
+
+	if err := vk.ExportSolidity(outFile); err != nil {
+		return C.CString(fmt.Sprintf("failed to export solidity verifier: %v", err))
+	}
+
+	return nil
+}
+
+// solidityCalldata mirrors the argument shape of the Verifier.sol
+// generated above: verifyProof(uint[2] a, uint[2][2] b, uint[2] c, uint[] input).
+type solidityCalldata struct {
+	A     [2]string    `json:"a"`
+	B     [2][2]string `json:"b"`
+	C     [2]string    `json:"c"`
+	Input []string     `json:"input"`
+}
+
+// gnark_groth16_proof_to_calldata reformats a hex-encoded WriteTo proof
+// and MarshalBinary public witness (the exact blobs gnark_groth16_prove
+// returns) into the decimal-string calldata shape Verifier.sol's
+// verifyProof expects, as a JSON document. BN254 only, for the same
+// reason as gnark_groth16_export_solidity.
+//
+//export gnark_groth16_proof_to_calldata
+func gnark_groth16_proof_to_calldata(proof_hex *C.char, public_inputs_hex *C.char) *C.char {
+	proofBytes, err := hex.DecodeString(C.GoString(proof_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode proof hex: %v", err))
+	}
+	proof := groth16.NewProof(ecc.BN254)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return C.CString(fmt.Sprintf("failed to deserialize proof: %v", err))
+	}
+	bn254Proof, ok := proof.(*groth16_bn254.Proof)
+	if !ok {
+		return C.CString(fmt.Sprintf("expected BN254 proof, got %T", proof))
+	}
+
+	pubBytes, err := hex.DecodeString(C.GoString(public_inputs_hex))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to decode public inputs hex: %v", err))
+	}
+	pubWitness, err := witness.New(ecc.BN254.ScalarField())
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to create witness: %v", err))
+	}
+	if err := pubWitness.UnmarshalBinary(pubBytes); err != nil {
+		return C.CString(fmt.Sprintf("failed to unmarshal public witness: %v", err))
+	}
+	pubVector, ok := pubWitness.Vector().(fr.Vector)
+	if !ok {
+		return C.CString(fmt.Sprintf("expected BN254 public witness, got %T", pubWitness.Vector()))
+	}
+
+	b, err := json.Marshal(buildSolidityCalldata(bn254Proof, pubVector))
+	if err != nil {
+		return C.CString(fmt.Sprintf("failed to marshal calldata: %v", err))
+	}
+
+	return C.CString(string(b))
+}
+
+// buildSolidityCalldata converts a BN254 proof and public witness into the
+// decimal-string shape Verifier.sol's verifyProof expects.
+//
+// The G2 point Bs needs its two Fp2 limbs swapped relative to
+// gnark-crypto's in-memory A0/A1 representation: the EVM pairing
+// precompile (and so gnark's own ExportSolidity template) expects each G2
+// coordinate encoded as {A1, A0}, not {A0, A1}.
+func buildSolidityCalldata(proof *groth16_bn254.Proof, pubVector fr.Vector) solidityCalldata {
+	calldata := solidityCalldata{
+		A: [2]string{
+			proof.Ar.X.String(),
+			proof.Ar.Y.String(),
+		},
+		B: [2][2]string{
+			{proof.Bs.X.A1.String(), proof.Bs.X.A0.String()},
+			{proof.Bs.Y.A1.String(), proof.Bs.Y.A0.String()},
+		},
+		C: [2]string{
+			proof.Krs.X.String(),
+			proof.Krs.Y.String(),
+		},
+		Input: make([]string, len(pubVector)),
+	}
+	for i, e := range pubVector {
+		calldata.Input[i] = e.String()
+	}
+	return calldata
+}
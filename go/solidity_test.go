@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// TestBuildSolidityCalldataSwapsG2Limbs guards against the EVM/gnark G2
+// encoding mismatch: the Fp2 limbs of the Bs proof point must come out as
+// {A1, A0}, not gnark-crypto's in-memory {A0, A1} order.
+func TestBuildSolidityCalldataSwapsG2Limbs(t *testing.T) {
+	var proof groth16_bn254.Proof
+	proof.Bs.X.A0.SetUint64(7)
+	proof.Bs.X.A1.SetUint64(11)
+	proof.Bs.Y.A0.SetUint64(13)
+	proof.Bs.Y.A1.SetUint64(17)
+
+	calldata := buildSolidityCalldata(&proof, fr.Vector{})
+
+	wantB := [2][2]string{
+		{"11", "7"},
+		{"17", "13"},
+	}
+	if calldata.B != wantB {
+		t.Fatalf("B limb ordering mismatch: got %v, want %v", calldata.B, wantB)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+/*
+#include "gnark.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// gnark_groth16_prove_fd and gnark_groth16_prove_buffers are binary
+// alternatives to gnark_groth16_prove for callers where hex round-tripping
+// the r1cs/pk/witness/proof through C.CString dominates wall time (large
+// circuits produce multi-MB artifacts). Both write raw WriteTo/MarshalBinary
+// bytes instead of hex, and report errors through an out-parameter rather
+// than a result struct so the common "no error" path stays allocation-free
+// on the Go side.
+
+// gnark_groth16_prove_fd reads the r1cs, proving key, and witness JSON
+// from the given file descriptors (which the caller may back with a plain
+// file or a memory-mapped/shared-memory file, e.g. one created with
+// memfd_create or under /dev/shm) and writes the raw binary proof and
+// public witness to proof_fd/public_fd. Returns 0 on success; on failure
+// returns -1 and sets *error_out to a newly allocated error string the
+// caller must release with gnark_free_string.
+//
+// Ownership of every fd passed in (r1cs_fd, pk_fd, witness_fd, proof_fd,
+// public_fd) transfers to this call: each is wrapped with os.NewFile and
+// closed before returning, success or failure. Callers must not reuse,
+// expect to read from, or close these fd numbers themselves afterward.
+//
+//export gnark_groth16_prove_fd
+func gnark_groth16_prove_fd(
+	curve C.int,
+	r1cs_fd C.int,
+	pk_fd C.int,
+	witness_fd C.int,
+	proof_fd C.int,
+	public_fd C.int,
+	error_out **C.char,
+) C.int {
+	r1csFile := os.NewFile(uintptr(r1cs_fd), "r1cs-fd")
+	defer r1csFile.Close()
+	pkFile := os.NewFile(uintptr(pk_fd), "pk-fd")
+	defer pkFile.Close()
+	witnessFile := os.NewFile(uintptr(witness_fd), "witness-fd")
+	defer witnessFile.Close()
+	proofFile := os.NewFile(uintptr(proof_fd), "proof-fd")
+	defer proofFile.Close()
+	publicFile := os.NewFile(uintptr(public_fd), "public-fd")
+	defer publicFile.Close()
+
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return failFD(error_out, err)
+	}
+
+	cs := groth16.NewCS(curveID)
+	if _, err := cs.ReadFrom(r1csFile); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to read r1cs: %w", err))
+	}
+
+	pk := groth16.NewProvingKey(curveID)
+	if _, err := pk.UnsafeReadFrom(pkFile); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to read proving key: %w", err))
+	}
+
+	witnessJSON, err := readAllString(witnessFile)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to read witness: %w", err))
+	}
+
+	fullWitness, err := buildWitnessFromJSON(witnessJSON, cs)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to build witness: %w", err))
+	}
+
+	proof, err := groth16.Prove(cs, pk, fullWitness)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("proof generation failed: %w", err))
+	}
+
+	if _, err := proof.WriteTo(proofFile); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to write proof: %w", err))
+	}
+
+	pubWitness, err := fullWitness.Public()
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to extract public witness: %w", err))
+	}
+	pubBin, err := pubWitness.MarshalBinary()
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to marshal public witness: %w", err))
+	}
+	if _, err := publicFile.Write(pubBin); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to write public witness: %w", err))
+	}
+
+	return 0
+}
+
+// gnark_groth16_prove_buffers is the fully in-memory counterpart of
+// gnark_groth16_prove_fd, for callers that already hold the r1cs/pk/witness
+// artifacts in Rust-owned buffers. witness_json is still a NUL-terminated
+// string (it's small relative to the binary artifacts); r1cs/pk and the
+// outputs are raw byte buffers.
+//
+// On success, *proof_out/*public_out are set to newly C-malloc'd buffers
+// (lengths in *proof_len_out/*public_len_out) that the caller must release
+// with gnark_free_buffer. On failure, returns -1 and sets *error_out as in
+// gnark_groth16_prove_fd.
+//
+//export gnark_groth16_prove_buffers
+func gnark_groth16_prove_buffers(
+	curve C.int,
+	r1cs_ptr *C.uint8_t,
+	r1cs_len C.size_t,
+	pk_ptr *C.uint8_t,
+	pk_len C.size_t,
+	witness_json *C.char,
+	proof_out **C.uint8_t,
+	proof_len_out *C.size_t,
+	public_out **C.uint8_t,
+	public_len_out *C.size_t,
+	error_out **C.char,
+) C.int {
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return failFD(error_out, err)
+	}
+
+	r1csBytes := C.GoBytes(unsafe.Pointer(r1cs_ptr), C.int(r1cs_len))
+	cs := groth16.NewCS(curveID)
+	if _, err := cs.ReadFrom(bytesReader(r1csBytes)); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to read r1cs: %w", err))
+	}
+
+	pkBytes := C.GoBytes(unsafe.Pointer(pk_ptr), C.int(pk_len))
+	pk := groth16.NewProvingKey(curveID)
+	if _, err := pk.UnsafeReadFrom(bytesReader(pkBytes)); err != nil {
+		return failFD(error_out, fmt.Errorf("failed to read proving key: %w", err))
+	}
+
+	fullWitness, err := buildWitnessFromJSON(C.GoString(witness_json), cs)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to build witness: %w", err))
+	}
+
+	proof, err := groth16.Prove(cs, pk, fullWitness)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("proof generation failed: %w", err))
+	}
+
+	proofBytes, err := writeToBytes(proof.WriteTo)
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to serialize proof: %w", err))
+	}
+	*proof_out = (*C.uint8_t)(C.CBytes(proofBytes))
+	*proof_len_out = C.size_t(len(proofBytes))
+
+	pubWitness, err := fullWitness.Public()
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to extract public witness: %w", err))
+	}
+	publicBytes, err := pubWitness.MarshalBinary()
+	if err != nil {
+		return failFD(error_out, fmt.Errorf("failed to marshal public witness: %w", err))
+	}
+	*public_out = (*C.uint8_t)(C.CBytes(publicBytes))
+	*public_len_out = C.size_t(len(publicBytes))
+
+	return 0
+}
+
+//export gnark_free_buffer
+func gnark_free_buffer(ptr unsafe.Pointer) {
+	if ptr != nil {
+		C.free(ptr)
+	}
+}
+
+// failFD is the shared error path for the fd/buffer exports: it allocates
+// the error string (if the caller wants one) and returns the -1 status
+// code both entry points use.
+func failFD(error_out **C.char, err error) C.int {
+	if error_out != nil {
+		*error_out = C.CString(err.Error())
+	}
+	return -1
+}
+
+func readAllString(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func bytesReader(b []byte) io.Reader {
+	return bytes.NewReader(b)
+}
+
+func writeToBytes(write func(w io.Writer) (int64, error)) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
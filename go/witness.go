@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+)
+
+// variableNames returns the ordered public/secret variable name slices
+// embedded in a compiled constraint system. gnark does not expose these
+// through the constraint.ConstraintSystem interface, so every concrete
+// constraint-system type, across every supported curve, has to be
+// type-switched individually. R1CS (Groth16) and SparseR1CS (PLONK) are
+// the same underlying type per curve package (both are aliases for that
+// package's unexported "system" type), so each curve only needs one case.
+func variableNames(cs constraint.ConstraintSystem) (public, secret []string, err error) {
+	switch t := cs.(type) {
+	case *cs_bn254.R1CS:
+		return t.Public, t.Secret, nil
+	case *cs_bls12381.R1CS:
+		return t.Public, t.Secret, nil
+	case *cs_bw6761.R1CS:
+		return t.Public, t.Secret, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+}
+
+// buildWitnessFromJSON creates a gnark witness from a JSON document
+// mapping circuit variable paths to decimal string values.
+//
+// Two equivalent shapes are accepted:
+//   - flat, dotted paths: {"Merkle.Path[3]": "7", "X": "1"}
+//   - nested, JSON-native shape: {"Merkle":{"Path":["1","2",...]}, "X":"1"}
+//
+// Both are flattened (see flattenWitnessJSON) into the same dotted/bracket
+// path space circuitschema.Walk uses. Matching that against the
+// constraint system's own Public/Secret name lists (produced by gnark's
+// internal schema walk, which this package doesn't control) is done
+// tolerant of separator choice -- see normalizeSeparators -- since nothing
+// here guarantees gnark spells nested paths with "." and "[idx]" rather
+// than, say, "_"-joining every level.
+//
+// This function accesses the constraint system's embedded variable name
+// lists (Public/Secret) to determine the correct ordering, then uses
+// witness.Fill to populate values. It works for both Groth16 (R1CS) and
+// PLONK (SparseR1CS) constraint systems on the curves variableNames
+// handles (BN254, BLS12-381, BW6-761); other curves return an error.
+func buildWitnessFromJSON(jsonStr string, cs constraint.ConstraintSystem) (witness.Witness, error) {
+	allPublic, allSecret, err := variableNames(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip "1" constant wire in public variables
+	return buildWitnessFromNames(jsonStr, cs.Field(), allPublic[1:], allSecret)
+}
+
+// buildWitnessFromNames is the name-list-driven core of
+// buildWitnessFromJSON, split out so long-lived sessions (see session.go)
+// can reuse a constraint system's public/secret name slices across many
+// calls instead of re-deriving them from the constraint system each time.
+// publicNames must already have the "1" constant wire stripped.
+func buildWitnessFromNames(jsonStr string, field *big.Int, publicNames, secretNames []string) (witness.Witness, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse witness JSON: %w", err)
+	}
+
+	flatMap := make(map[string]interface{})
+	if err := flattenWitnessJSON(doc, "", flatMap); err != nil {
+		return nil, err
+	}
+	normalizedMap := make(map[string]interface{}, len(flatMap))
+	for k, v := range flatMap {
+		normalizedMap[normalizeSeparators(k)] = v
+	}
+
+	nbPublic := len(publicNames)
+	nbSecret := len(secretNames)
+
+	// Create a buffered channel to feed values in witness order:
+	// public variables first, then secret variables.
+	values := make(chan any, nbPublic+nbSecret)
+
+	lookup := func(name string) (interface{}, bool) {
+		if val, exists := flatMap[name]; exists {
+			return val, true
+		}
+		val, exists := normalizedMap[normalizeSeparators(name)]
+		return val, exists
+	}
+
+	for _, name := range publicNames {
+		val, exists := lookup(name)
+		if !exists {
+			return nil, fmt.Errorf("missing witness value for public variable at path %q", name)
+		}
+		values <- toFieldElement(val)
+	}
+
+	for _, name := range secretNames {
+		val, exists := lookup(name)
+		if !exists {
+			return nil, fmt.Errorf("missing witness value for secret variable at path %q", name)
+		}
+		values <- toFieldElement(val)
+	}
+	close(values)
+
+	w, err := witness.New(field)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create witness: %w", err)
+	}
+
+	if err := w.Fill(nbPublic, nbSecret, values); err != nil {
+		return nil, fmt.Errorf("failed to fill witness: %w", err)
+	}
+
+	return w, nil
+}
+
+// flattenWitnessJSON walks a parsed witness JSON document and records
+// every scalar leaf under flat, such that both of the accepted shapes --
+// already-dotted keys and nested objects/arrays -- end up addressed the
+// same way: "Merkle.Path[3]".
+//
+// A string-keyed map whose keys are themselves dotted paths (the flat
+// shape) is left alone rather than re-descended into, since those keys
+// are not JSON structure, just path strings the caller chose to spell out.
+func flattenWitnessJSON(node interface{}, path string, flat map[string]interface{}) error {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if err := flattenWitnessJSON(child, childPath, flat); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		if path == "" {
+			return fmt.Errorf("witness JSON root must be an object")
+		}
+		for i, child := range v {
+			if err := flattenWitnessJSON(child, fmt.Sprintf("%s[%d]", path, i), flat); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if path == "" {
+			return fmt.Errorf("witness JSON root must be an object")
+		}
+		flat[path] = v
+		return nil
+	}
+}
+
+// normalizeSeparators collapses the dotted/bracket path spelling
+// (flattenWitnessJSON's and circuitschema.Walk's convention) down to a
+// plain underscore join, e.g. "Merkle.Path[3]" -> "Merkle_Path_3". Two
+// paths that differ only in whether nesting is spelled with "." and
+// "[idx]" or with "_" compare equal after normalization, which is what
+// buildWitnessFromNames relies on to match JSON-derived paths against the
+// constraint system's own name lists without assuming which spelling
+// gnark's internal schema walk actually uses.
+func normalizeSeparators(path string) string {
+	var b strings.Builder
+	b.Grow(len(path))
+	for _, r := range path {
+		switch r {
+		case '.', '[':
+			b.WriteByte('_')
+		case ']':
+			// dropped: "[3]" becomes "_3", not "_3_"
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// toFieldElement converts a JSON value to a type gnark accepts as a field element.
+// gnark field elements can be constructed from: string (decimal), int64, *big.Int.
+func toFieldElement(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return val // gnark accepts decimal strings directly
+	case float64:
+		// JSON numbers are decoded as float64 by default
+		return int64(val)
+	case json.Number:
+		return val.String()
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
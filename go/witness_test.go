@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenWitnessJSONNested(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{"X":"1","Merkle":{"Path":["7","8","9"]}}`), &doc); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	flat := make(map[string]interface{})
+	if err := flattenWitnessJSON(doc, "", flat); err != nil {
+		t.Fatalf("flattenWitnessJSON failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"X":              "1",
+		"Merkle.Path[0]": "7",
+		"Merkle.Path[1]": "8",
+		"Merkle.Path[2]": "9",
+	}
+	if !reflect.DeepEqual(flat, want) {
+		t.Fatalf("flatten mismatch:\n got  %v\n want %v", flat, want)
+	}
+}
+
+func TestFlattenWitnessJSONFlatDottedKeysLeftAlone(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{"Merkle.Path[3]":"7","X":"1"}`), &doc); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	flat := make(map[string]interface{})
+	if err := flattenWitnessJSON(doc, "", flat); err != nil {
+		t.Fatalf("flattenWitnessJSON failed: %v", err)
+	}
+
+	want := map[string]interface{}{"Merkle.Path[3]": "7", "X": "1"}
+	if !reflect.DeepEqual(flat, want) {
+		t.Fatalf("flatten mismatch:\n got  %v\n want %v", flat, want)
+	}
+}
+
+func TestFlattenWitnessJSONRejectsNonObjectRoot(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`"not an object"`), &doc); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	if err := flattenWitnessJSON(doc, "", map[string]interface{}{}); err == nil {
+		t.Fatal("expected an error for a non-object witness JSON root")
+	}
+}
+
+func TestNormalizeSeparators(t *testing.T) {
+	cases := map[string]string{
+		"Merkle.Path[3]": "Merkle_Path_3",
+		"X":              "X",
+		"Merkle_Path_3":  "Merkle_Path_3",
+		"A[0].B[1]":      "A_0_B_1",
+	}
+	for in, want := range cases {
+		if got := normalizeSeparators(in); got != want {
+			t.Errorf("normalizeSeparators(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestNormalizedPathsResolveUnderscoreJoinedNames exercises the
+// fallback buildWitnessFromNames relies on when a constraint system's
+// variable names turn out to use "_"-joined nesting instead of the
+// dotted/bracket convention flattenWitnessJSON and circuitschema.Walk
+// produce (see normalizeSeparators). It rebuilds that normalized lookup
+// directly rather than calling buildWitnessFromNames.
+func TestNormalizedPathsResolveUnderscoreJoinedNames(t *testing.T) {
+	flat := make(map[string]interface{})
+	if err := flattenWitnessJSON(map[string]interface{}{
+		"Merkle": map[string]interface{}{"Path": []interface{}{"7", "8"}},
+	}, "", flat); err != nil {
+		t.Fatalf("flattenWitnessJSON failed: %v", err)
+	}
+
+	normalized := make(map[string]interface{}, len(flat))
+	for k, v := range flat {
+		normalized[normalizeSeparators(k)] = v
+	}
+
+	for _, name := range []string{"Merkle_Path_0", "Merkle_Path_1"} {
+		if _, ok := normalized[normalizeSeparators(name)]; !ok {
+			t.Errorf("expected underscore-joined name %q to resolve via normalization", name)
+		}
+	}
+}
@@ -1,33 +1,20 @@
 package main
 
 /*
-#include <stdlib.h>
-
-// Result struct for Groth16 proof generation.
-// On success: proof and public_inputs are set, error is NULL.
-// On failure: error is set, proof and public_inputs are NULL.
-typedef struct {
-    char *proof;          // hex-encoded binary proof (WriteTo serialization)
-    char *public_inputs;  // hex-encoded binary public witness (MarshalBinary)
-    char *error;          // error message or NULL on success
-} C_Groth16ProofResult;
+#include "gnark.h"
 */
 import "C"
 
 import (
 	"bytes"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
 	"unsafe"
 
-	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/backend/witness"
-	"github.com/consensys/gnark/constraint"
-	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
 )
 
 //export gnark_init
@@ -42,16 +29,23 @@ func gnark_init() C.int {
 
 //export gnark_groth16_prove
 func gnark_groth16_prove(
+	curve C.int,
 	r1cs_path *C.char,
 	pk_path *C.char,
 	witness_json *C.char,
-) *C.C_Groth16ProofResult {
-	result := (*C.C_Groth16ProofResult)(C.malloc(C.size_t(unsafe.Sizeof(C.C_Groth16ProofResult{}))))
+) *C.C_ProofResult {
+	result := (*C.C_ProofResult)(C.malloc(C.size_t(unsafe.Sizeof(C.C_ProofResult{}))))
 	result.proof = nil
 	result.public_inputs = nil
 	result.error = nil
 
-	cs := groth16.NewCS(ecc.BN254)
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		result.error = C.CString(err.Error())
+		return result
+	}
+
+	cs := groth16.NewCS(curveID)
 	r1csFile, err := os.Open(C.GoString(r1cs_path))
 	if err != nil {
 		result.error = C.CString(fmt.Sprintf("failed to open r1cs file: %v", err))
@@ -65,7 +59,7 @@ func gnark_groth16_prove(
 	}
 
 	// UnsafeReadFrom for speed -- trusted local file, skip validation
-	pk := groth16.NewProvingKey(ecc.BN254)
+	pk := groth16.NewProvingKey(curveID)
 	pkFile, err := os.Open(C.GoString(pk_path))
 	if err != nil {
 		result.error = C.CString(fmt.Sprintf("failed to open pk file: %v", err))
@@ -115,12 +109,18 @@ func gnark_groth16_prove(
 
 //export gnark_groth16_verify
 func gnark_groth16_verify(
+	curve C.int,
 	r1cs_path *C.char,
 	vk_path *C.char,
 	proof_hex *C.char,
 	public_inputs_hex *C.char,
 ) *C.char {
-	cs := groth16.NewCS(ecc.BN254)
+	curveID, err := curveFromID(curve)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	cs := groth16.NewCS(curveID)
 	r1csFile, err := os.Open(C.GoString(r1cs_path))
 	if err != nil {
 		return C.CString(fmt.Sprintf("failed to open r1cs file: %v", err))
@@ -131,7 +131,7 @@ func gnark_groth16_verify(
 		return C.CString(fmt.Sprintf("failed to read r1cs: %v", err))
 	}
 
-	vk := groth16.NewVerifyingKey(ecc.BN254)
+	vk := groth16.NewVerifyingKey(curveID)
 	vkFile, err := os.Open(C.GoString(vk_path))
 	if err != nil {
 		return C.CString(fmt.Sprintf("failed to open vk file: %v", err))
@@ -146,7 +146,7 @@ func gnark_groth16_verify(
 	if err != nil {
 		return C.CString(fmt.Sprintf("failed to decode proof hex: %v", err))
 	}
-	proof := groth16.NewProof(ecc.BN254)
+	proof := groth16.NewProof(curveID)
 	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
 		return C.CString(fmt.Sprintf("failed to deserialize proof: %v", err))
 	}
@@ -155,7 +155,7 @@ func gnark_groth16_verify(
 	if err != nil {
 		return C.CString(fmt.Sprintf("failed to decode public inputs hex: %v", err))
 	}
-	pubWitness, err := witness.New(ecc.BN254.ScalarField())
+	pubWitness, err := witness.New(curveID.ScalarField())
 	if err != nil {
 		return C.CString(fmt.Sprintf("failed to create witness: %v", err))
 	}
@@ -172,7 +172,7 @@ func gnark_groth16_verify(
 }
 
 //export gnark_free_proof_result
-func gnark_free_proof_result(r *C.C_Groth16ProofResult) {
+func gnark_free_proof_result(r *C.C_ProofResult) {
 	if r == nil {
 		return
 	}
@@ -195,82 +195,4 @@ func gnark_free_string(s *C.char) {
 	}
 }
 
-// buildWitnessFromJSON creates a gnark witness from a JSON object mapping
-// circuit variable names to decimal string values.
-//
-// The JSON format is: {"VarName": "decimal_value", ...}
-// Variable names must match those defined in the circuit (via gnark struct tags).
-//
-// This function accesses the R1CS's embedded variable name lists (Public/Secret)
-// to determine the correct ordering, then uses witness.Fill to populate values.
-func buildWitnessFromJSON(jsonStr string, cs constraint.ConstraintSystem) (witness.Witness, error) {
-	var flatMap map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &flatMap); err != nil {
-		return nil, fmt.Errorf("failed to parse witness JSON: %w", err)
-	}
-
-	// Type-assert to the concrete BN254 R1CS to access variable name lists.
-	// The constraint.System struct (embedded in R1CS) stores Public and Secret
-	// variable names as []string. Public[0] is always "1" (the constant wire).
-	r1cs, ok := cs.(*cs_bn254.R1CS)
-	if !ok {
-		return nil, fmt.Errorf("expected BN254 R1CS, got %T", cs)
-	}
-
-	// Skip "1" constant wire in public variables
-	publicNames := r1cs.Public[1:]
-	secretNames := r1cs.Secret
-
-	nbPublic := len(publicNames)
-	nbSecret := len(secretNames)
-
-	// Create a buffered channel to feed values in witness order:
-	// public variables first, then secret variables.
-	values := make(chan any, nbPublic+nbSecret)
-
-	for _, name := range publicNames {
-		val, exists := flatMap[name]
-		if !exists {
-			return nil, fmt.Errorf("missing witness value for public variable %q", name)
-		}
-		values <- toFieldElement(val)
-	}
-
-	for _, name := range secretNames {
-		val, exists := flatMap[name]
-		if !exists {
-			return nil, fmt.Errorf("missing witness value for secret variable %q", name)
-		}
-		values <- toFieldElement(val)
-	}
-	close(values)
-
-	w, err := witness.New(cs.Field())
-	if err != nil {
-		return nil, fmt.Errorf("failed to create witness: %w", err)
-	}
-
-	if err := w.Fill(nbPublic, nbSecret, values); err != nil {
-		return nil, fmt.Errorf("failed to fill witness: %w", err)
-	}
-
-	return w, nil
-}
-
-// toFieldElement converts a JSON value to a type gnark accepts as a field element.
-// gnark field elements can be constructed from: string (decimal), int64, *big.Int.
-func toFieldElement(v interface{}) interface{} {
-	switch val := v.(type) {
-	case string:
-		return val // gnark accepts decimal strings directly
-	case float64:
-		// JSON numbers are decoded as float64 by default
-		return int64(val)
-	case json.Number:
-		return val.String()
-	default:
-		return fmt.Sprintf("%v", val)
-	}
-}
-
 func main() {} // required for c-archive build mode